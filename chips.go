@@ -0,0 +1,81 @@
+//go:build linux
+
+package sysfspwm
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/knieriem/sysfspwm/internal/devattr"
+)
+
+// ChipInfo describes a PWM chip found under /sys/class/pwm.
+type ChipInfo struct {
+	// Index is the chip's number, as it appears in its "pwmchipN"
+	// directory name.
+	Index int
+
+	// NumChannels is the number of channels the chip provides,
+	// as reported by its "npwm" attribute.
+	NumChannels int
+
+	// ExportedChannels holds the indices of the chip's channels that
+	// are currently exported, i.e. that have a "pwmN" subdirectory.
+	ExportedChannels []int
+
+	// Path is the chip's sysfs directory, e.g. "/sys/class/pwm/pwmchip0".
+	Path string
+}
+
+// ListChips scans /sys/class/pwm for available PWM chips, so that
+// callers can build auto-configuration or CLI tools without having to
+// hard-code chip numbers.
+func ListChips() ([]ChipInfo, error) {
+	matches, err := filepath.Glob(sysfsPrefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	chips := make([]ChipInfo, 0, len(matches))
+	for _, path := range matches {
+		idx, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(path), "pwmchip"))
+		if err != nil {
+			continue
+		}
+		numChan, err := devattr.ReadIntFile(path, "npwm")
+		if err != nil {
+			return nil, err
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		var exported []int
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			s := strings.TrimPrefix(e.Name(), "pwm")
+			if s == e.Name() {
+				continue
+			}
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				continue
+			}
+			exported = append(exported, n)
+		}
+		sort.Ints(exported)
+		chips = append(chips, ChipInfo{
+			Index:            idx,
+			NumChannels:      numChan,
+			ExportedChannels: exported,
+			Path:             path,
+		})
+	}
+	return chips, nil
+}
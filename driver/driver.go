@@ -0,0 +1,22 @@
+// Package driver defines a pluggable abstraction for programming PWM
+// outputs, so that callers of sysfspwm are not hard-wired to the Linux
+// kernel's PWM sysfs interface and can target alternatives such as the
+// userspace pi-blaster daemon instead.
+package driver
+
+// Channel represents a single PWM output opened through a Driver.
+type Channel interface {
+	// PWM configures the channel's frequency and duty cycle, following
+	// the same conventions as sysfspwm.Channel.PWM: duty is in the
+	// range [0, 1<<24], with the maximum value corresponding to 100%,
+	// and freq has a resolution of 1 millihertz.
+	PWM(duty int32, freq int64) error
+
+	// Close releases the channel.
+	Close() error
+}
+
+// Driver opens PWM channels identified by a chip and a channel index.
+type Driver interface {
+	OpenChannel(chip, channel int) (Channel, error)
+}
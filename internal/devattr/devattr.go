@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 const debug = false
@@ -13,6 +14,7 @@ const debug = false
 type File struct {
 	f         io.ReadWriteCloser
 	value     int64
+	strValue  string
 	debugInfo func(data string)
 }
 
@@ -90,6 +92,31 @@ func (attr *File) ReadInt() (int, error) {
 	return int(i), nil
 }
 
+// StringValue returns the string value last read from, or written to,
+// the attribute file, without touching the file itself.
+func (attr *File) StringValue() string {
+	return attr.strValue
+}
+
+func (attr *File) ReadString() (string, error) {
+	b, err := io.ReadAll(attr.f)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSpace(string(b))
+	attr.strValue = s
+	return s, nil
+}
+
+func (attr *File) WriteString(s string) error {
+	if attr.strValue == s {
+		return nil
+	}
+	attr.strValue = s
+	_, err := attr.write([]byte(s))
+	return err
+}
+
 func ReadIntFile(dir, attrName string) (int, error) {
 	f, err := Open(dir, attrName, os.O_RDONLY)
 	if err != nil {
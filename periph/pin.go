@@ -0,0 +1,117 @@
+//go:build linux
+
+// Package periph adapts a sysfspwm.Channel to the gpio.PinIO and
+// gpio.PinOut interfaces defined by periph.io, so that a Channel can
+// be plugged into any periph.io driver stack that consumes a
+// gpio.PinOut.
+package periph
+
+import (
+	"fmt"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+
+	"github.com/knieriem/sysfspwm"
+)
+
+// defaultFrequency is used for Out(gpio.High) and Out(gpio.Low) until
+// PWM has been called at least once.
+const defaultFrequency = 50 * physic.Hertz
+
+// pin adapts a *sysfspwm.Channel to gpio.PinIO.
+type pin struct {
+	ch       *sysfspwm.Channel
+	name     string
+	number   int
+	lastFreq int64 // in millihertz, as accepted by sysfspwm.Channel.PWM
+}
+
+// AsPin wraps ch as a gpio.PinIO identified by name and number. Since
+// a PWM channel cannot be read, the PinIn side of the returned pin
+// always reports gpio.Low and rejects In.
+func AsPin(ch *sysfspwm.Channel, name string, number int) gpio.PinIO {
+	return newPin(ch, name, number)
+}
+
+// AsPinOut wraps ch as a gpio.PinOut identified by name and number.
+func AsPinOut(ch *sysfspwm.Channel, name string, number int) gpio.PinOut {
+	return newPin(ch, name, number)
+}
+
+func newPin(ch *sysfspwm.Channel, name string, number int) *pin {
+	return &pin{
+		ch:       ch,
+		name:     name,
+		number:   number,
+		lastFreq: freqToMilliHertz(defaultFrequency),
+	}
+}
+
+func (p *pin) String() string {
+	return fmt.Sprintf("%s(%d)", p.name, p.number)
+}
+
+func (p *pin) Name() string {
+	return p.name
+}
+
+func (p *pin) Number() int {
+	return p.number
+}
+
+func (p *pin) Function() string {
+	return "PWM"
+}
+
+// Halt disables the channel.
+func (p *pin) Halt() error {
+	return p.ch.PWM(0, 0)
+}
+
+// Out maps gpio.High and gpio.Low to a 100%, respectively 0%, duty
+// cycle at the frequency last used by PWM (or 50 Hz, if PWM has not
+// been called yet).
+func (p *pin) Out(l gpio.Level) error {
+	if l == gpio.High {
+		return p.ch.PWM(sysfspwm.DutyMax, p.lastFreq)
+	}
+	return p.ch.PWM(0, p.lastFreq)
+}
+
+// PWM converts duty and f from periph.io's units to the ones expected
+// by sysfspwm.Channel.PWM, and programs the channel accordingly.
+func (p *pin) PWM(duty gpio.Duty, f physic.Frequency) error {
+	d := int32(int64(duty) * int64(sysfspwm.DutyMax) / int64(gpio.DutyMax))
+	p.lastFreq = freqToMilliHertz(f)
+	return p.ch.PWM(d, p.lastFreq)
+}
+
+func freqToMilliHertz(f physic.Frequency) int64 {
+	return int64(f / physic.MilliHertz)
+}
+
+// In always fails: a PWM channel has no input side.
+func (p *pin) In(pull gpio.Pull, edge gpio.Edge) error {
+	return fmt.Errorf("sysfspwm/periph: %s is output-only", p.name)
+}
+
+// Read always returns gpio.Low, as a PWM channel cannot be read.
+func (p *pin) Read() gpio.Level {
+	return gpio.Low
+}
+
+// WaitForEdge always returns false immediately, as a PWM channel
+// never reports edges.
+func (p *pin) WaitForEdge(timeout time.Duration) bool {
+	return false
+}
+
+func (p *pin) Pull() gpio.Pull {
+	return gpio.PullNoChange
+}
+
+func (p *pin) DefaultPull() gpio.Pull {
+	return gpio.PullNoChange
+}
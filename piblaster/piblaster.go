@@ -0,0 +1,75 @@
+// Package piblaster implements the driver.Driver interface on top of
+// pi-blaster (https://github.com/sarfata/pi-blaster), a userspace
+// daemon that drives PWM on arbitrary Raspberry Pi GPIO pins through a
+// FIFO, as an alternative to the kernel's PWM sysfs interface.
+package piblaster
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/knieriem/sysfspwm/driver"
+)
+
+// DefaultPath is the FIFO pi-blaster listens on by default.
+const DefaultPath = "/dev/pi-blaster"
+
+// dutyMax mirrors sysfspwm.DutyMax: the duty argument passed to
+// Channel.PWM is in the range [0, dutyMax].
+const dutyMax = 1 << 24
+
+// PiBlasterDriver implements driver.Driver by writing commands to the
+// pi-blaster FIFO.
+type PiBlasterDriver struct {
+	// Path is the pi-blaster FIFO to write to. If empty, DefaultPath
+	// is used.
+	Path string
+}
+
+func (d PiBlasterDriver) path() string {
+	if d.Path != "" {
+		return d.Path
+	}
+	return DefaultPath
+}
+
+// OpenChannel opens the pi-blaster FIFO and returns a channel driving
+// the GPIO pin identified by the gpio argument. The chip argument
+// is unused, and should be 0; it is present only to satisfy
+// driver.Driver.
+func (d PiBlasterDriver) OpenChannel(chip, gpio int) (driver.Channel, error) {
+	f, err := os.OpenFile(d.path(), os.O_WRONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &channel{f: f, gpio: gpio}, nil
+}
+
+type channel struct {
+	f    *os.File
+	gpio int
+}
+
+// PWM maps duty onto the 0.0..1.0 range expected by pi-blaster and
+// writes it to the FIFO. freq is ignored, as pi-blaster always runs
+// its PWM cycle at a fixed frequency.
+func (ch *channel) PWM(duty int32, freq int64) error {
+	if duty < 0 {
+		duty = 0
+	} else if duty > dutyMax {
+		duty = dutyMax
+	}
+	frac := float64(duty) / float64(dutyMax)
+	_, err := fmt.Fprintf(ch.f, "%d=%.3f\n", ch.gpio, frac)
+	return err
+}
+
+// Close releases the GPIO pin by writing a "release" command to the
+// pi-blaster FIFO, then closes the FIFO.
+func (ch *channel) Close() error {
+	_, err := fmt.Fprintf(ch.f, "release %d\n", ch.gpio)
+	if err1 := ch.f.Close(); err1 != nil && err == nil {
+		err = err1
+	}
+	return err
+}
@@ -4,12 +4,14 @@
 package sysfspwm
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
+	"github.com/knieriem/sysfspwm/driver"
 	"github.com/knieriem/sysfspwm/internal/devattr"
 )
 
@@ -19,6 +21,10 @@ type Channel struct {
 	enable    *devattr.File
 	period    *devattr.File
 	dutyCycle *devattr.File
+	polarity  *devattr.File
+
+	chip, channel int
+	exportedByUs  bool
 }
 
 // OpenChannel returns a handle corresponding to the
@@ -31,12 +37,15 @@ type Channel struct {
 // not present yet, this function will try to make it available first,
 // by writing to the device's "export" file.
 func OpenChannel(chip, channel int) (*Channel, error) {
-	dir, err := chanDir(chip, channel)
+	dir, exported, err := chanDir(chip, channel)
 	if err != nil {
 		return nil, err
 	}
 
 	ch := new(Channel)
+	ch.chip = chip
+	ch.channel = channel
+	ch.exportedByUs = exported
 	ch.enable, err = devattr.Open(dir, "enable", os.O_RDWR)
 	if err != nil {
 		return nil, err
@@ -52,6 +61,16 @@ func OpenChannel(chip, channel int) (*Channel, error) {
 		ch.dutyCycle.Close()
 		return nil, err
 	}
+	ch.polarity, err = devattr.Open(dir, "polarity", os.O_RDWR)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			ch.Close()
+			return nil, err
+		}
+		// Older drivers do not expose a "polarity" attribute;
+		// Polarity/SetPolarity will report ErrPolarityUnsupported.
+		ch.polarity = nil
+	}
 
 	i, err := ch.enable.ReadInt()
 	if err != nil {
@@ -67,36 +86,56 @@ func OpenChannel(chip, channel int) (*Channel, error) {
 			return nil, err
 		}
 	}
+	if ch.polarity != nil {
+		// Cache the current polarity so a later SetPolarity call
+		// with the same value can no-op.
+		_, err = ch.polarity.ReadString()
+		if err != nil {
+			ch.Close()
+			return nil, err
+		}
+	}
 	return ch, err
 }
 
-func chanDir(chip, channel int) (string, error) {
+// SysfsDriver implements driver.Driver using the Linux kernel's PWM
+// sysfs interface, by delegating to OpenChannel.
+type SysfsDriver struct{}
+
+func (SysfsDriver) OpenChannel(chip, channel int) (driver.Channel, error) {
+	return OpenChannel(chip, channel)
+}
+
+// chanDir returns the sysfs directory for the given chip and channel,
+// exporting it first if necessary. The second return value reports
+// whether this call was the one that exported the channel.
+func chanDir(chip, channel int) (string, bool, error) {
 	devDir := sysfsPrefix + strconv.Itoa(chip)
 	d := filepath.Join(devDir, "pwm"+strconv.Itoa(channel))
 	if fi, err := os.Stat(d); err == nil && fi.IsDir() {
-		return d, nil
+		return d, false, nil
 	}
 
 	numChan, err := devattr.ReadIntFile(devDir, "npwm")
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 	if channel >= numChan {
-		return "", fmt.Errorf("pwmchip%d: channel index (%d) exceeds number of channels (%d)", chip, channel, numChan)
+		return "", false, fmt.Errorf("pwmchip%d: channel index (%d) exceeds number of channels (%d)", chip, channel, numChan)
 	}
 	err = devattr.WriteIntFile(devDir, "export", channel)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 	retries := 20
 	for retries > 0 {
 		time.Sleep(100 * time.Millisecond)
 		if fi, err := os.Stat(d); err == nil && fi.IsDir() {
-			return d, nil
+			return d, true, nil
 		}
 		retries--
 	}
-	return "", fmt.Errorf("pwmchip%d: could not export channel %d", chip, channel)
+	return "", false, fmt.Errorf("pwmchip%d: could not export channel %d", chip, channel)
 }
 
 const (
@@ -131,7 +170,8 @@ func (ch *Channel) PWM(duty int32, freq int64) error {
 }
 
 // Close calls close on the channel's underlying
-// sysfs attribute files "enable", "duty_cycle", and "period".
+// sysfs attribute files "enable", "duty_cycle", and "period"
+// (and "polarity", if present).
 func (ch *Channel) Close() error {
 	err := ch.enable.Close()
 	if err1 := ch.dutyCycle.Close(); err1 != nil && err == nil {
@@ -140,5 +180,99 @@ func (ch *Channel) Close() error {
 	if err1 := ch.period.Close(); err1 != nil && err == nil {
 		err = err1
 	}
+	if ch.polarity != nil {
+		if err1 := ch.polarity.Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}
 	return err
 }
+
+// CloseAndUnexport closes the channel like Close, and additionally
+// writes the channel index to the chip's "unexport" file, so that it
+// no longer shows up under /sys/class/pwm/pwmchipN once the process
+// exits. The unexport step is skipped if this process was not the one
+// that exported the channel in the first place, so as to not disturb
+// a channel set up by someone else.
+func (ch *Channel) CloseAndUnexport() error {
+	err := ch.Close()
+	if ch.exportedByUs {
+		devDir := sysfsPrefix + strconv.Itoa(ch.chip)
+		if err1 := devattr.WriteIntFile(devDir, "unexport", ch.channel); err1 != nil && err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
+// Polarity describes the polarity of the PWM signal generated on a channel.
+type Polarity int
+
+const (
+	PolarityNormal Polarity = iota
+	PolarityInverted
+)
+
+func (p Polarity) String() string {
+	if p == PolarityInverted {
+		return "inversed"
+	}
+	return "normal"
+}
+
+func parsePolarity(s string) (Polarity, error) {
+	switch s {
+	case "normal":
+		return PolarityNormal, nil
+	case "inversed":
+		return PolarityInverted, nil
+	}
+	return 0, fmt.Errorf("sysfspwm: unrecognized polarity value %q", s)
+}
+
+// ErrPolarityUnsupported is returned by Polarity and SetPolarity
+// when the channel's sysfs directory does not contain a "polarity"
+// attribute file.
+var ErrPolarityUnsupported = errors.New("sysfspwm: channel does not support the polarity attribute")
+
+// Polarity returns the channel's current polarity, as cached by
+// OpenChannel and SetPolarity.
+func (ch *Channel) Polarity() (Polarity, error) {
+	if ch.polarity == nil {
+		return 0, ErrPolarityUnsupported
+	}
+	return parsePolarity(ch.polarity.StringValue())
+}
+
+// SetPolarity sets the channel's polarity. Since most drivers reject
+// polarity writes while the channel is enabled, the channel is
+// disabled first if necessary, and re-enabled afterwards.
+// SetPolarity is a no-op if the channel already has the requested
+// polarity.
+func (ch *Channel) SetPolarity(p Polarity) error {
+	if ch.polarity == nil {
+		return ErrPolarityUnsupported
+	}
+	if ch.polarity.StringValue() == p.String() {
+		return nil
+	}
+	wasEnabled := !ch.enable.IsZero()
+	if wasEnabled {
+		if err := ch.enable.Write0(); err != nil {
+			return err
+		}
+	}
+	if err := ch.polarity.WriteString(p.String()); err != nil {
+		if wasEnabled {
+			// Best-effort: restore the previous enable state so a
+			// failed polarity write doesn't silently leave the
+			// channel disabled.
+			ch.enable.Write1()
+		}
+		return err
+	}
+	if wasEnabled {
+		return ch.enable.Write1()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+//go:build linux
+
+package sysfspwm
+
+import "time"
+
+// ServoOptions configures a Servo. Zero values select timings typical
+// of standard hobby servos, as described for each field.
+type ServoOptions struct {
+	// Frequency is the PWM frequency driving the servo, in Hz.
+	// Defaults to 50 Hz, i.e. a 20 ms period.
+	Frequency float64
+
+	// MinPulse and MaxPulse are the pulse widths corresponding to
+	// MinAngle and MaxAngle, respectively. They default to 0.5 ms
+	// and 2.5 ms.
+	MinPulse, MaxPulse time.Duration
+
+	// MinAngle and MaxAngle describe the angular range covered by
+	// MinPulse and MaxPulse. If both are zero, they default to 0 and
+	// 180 degrees; otherwise they are used as given.
+	MinAngle, MaxAngle float64
+}
+
+// Servo drives a standard hobby servo motor connected to a Channel.
+type Servo struct {
+	ch       *Channel
+	freq     int64 // in millihertz, as accepted by Channel.PWM
+	period   time.Duration
+	minPulse time.Duration
+	maxPulse time.Duration
+	minAngle float64
+	maxAngle float64
+}
+
+// NewServo returns a Servo driven through ch, using the timings given
+// by opts.
+func NewServo(ch *Channel, opts ServoOptions) *Servo {
+	freq := opts.Frequency
+	if freq == 0 {
+		freq = 50
+	}
+	minPulse := opts.MinPulse
+	if minPulse == 0 {
+		minPulse = 500 * time.Microsecond
+	}
+	maxPulse := opts.MaxPulse
+	if maxPulse == 0 {
+		maxPulse = 2500 * time.Microsecond
+	}
+	minAngle, maxAngle := opts.MinAngle, opts.MaxAngle
+	if minAngle == 0 && maxAngle == 0 {
+		maxAngle = 180
+	}
+	return &Servo{
+		ch:       ch,
+		freq:     int64(freq * 1000),
+		period:   time.Duration(float64(time.Second) / freq),
+		minPulse: minPulse,
+		maxPulse: maxPulse,
+		minAngle: minAngle,
+		maxAngle: maxAngle,
+	}
+}
+
+// SetAngle moves the servo to deg degrees, clamping it to the
+// [MinAngle, MaxAngle] range configured for the servo.
+func (s *Servo) SetAngle(deg float64) error {
+	if deg < s.minAngle {
+		deg = s.minAngle
+	} else if deg > s.maxAngle {
+		deg = s.maxAngle
+	}
+	frac := (deg - s.minAngle) / (s.maxAngle - s.minAngle)
+	pulse := s.minPulse + time.Duration(frac*float64(s.maxPulse-s.minPulse))
+	return s.setPulseWidth(pulse)
+}
+
+// SetPulseWidth programs the servo with a raw pulse width d, clamping
+// it to [0, period].
+func (s *Servo) SetPulseWidth(d time.Duration) error {
+	if d < 0 {
+		d = 0
+	} else if d > s.period {
+		d = s.period
+	}
+	return s.setPulseWidth(d)
+}
+
+func (s *Servo) setPulseWidth(d time.Duration) error {
+	duty := int32(int64(DutyMax) * int64(d) / int64(s.period))
+	return s.ch.PWM(duty, s.freq)
+}
+
+// Disable releases torque on the servo by writing 0 to the channel's
+// "enable" attribute, without closing the underlying Channel.
+func (s *Servo) Disable() error {
+	return s.ch.enable.Write0()
+}